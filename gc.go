@@ -0,0 +1,35 @@
+package guardian
+
+import (
+	"context"
+	"time"
+)
+
+// StartGC launches a goroutine that calls the manager's store's GC method
+// every interval, sweeping sessions that have expired or gone invalid.
+// Stores that already expire entries on their own (e.g. Redis via TTLs)
+// can make GC a no-op; StartGC still ticks for them so Manager's behavior
+// doesn't depend on which backend is in use.
+//
+// Calling the returned stop func cancels the goroutine. It is safe to call
+// stop more than once and safe to call StartGC from multiple managers
+// sharing a store, since GC itself must be idempotent.
+func (man *Manager) StartGC(interval time.Duration) (stop func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				man.store.GC(ctx)
+			}
+		}
+	}()
+
+	return cancel
+}
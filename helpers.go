@@ -2,9 +2,11 @@ package guardian
 
 import (
 	"crypto/md5"
+	"crypto/rand"
+	"encoding/base64"
 	"encoding/hex"
 	"fmt"
-	"time"
+	"sync"
 )
 
 func newContextKey(name string) contextKey {
@@ -13,21 +15,53 @@ func newContextKey(name string) contextKey {
 	return contextKey(key)
 }
 
-func (man *Manager) newSessionID() string {
-	binData := md5.Sum([]byte(man.name + "" + fmt.Sprint(time.Now().UnixNano())))
-	key := hex.EncodeToString(binData[:])
-	return (key)
+// IDGenerator produces a new, unique session ID. The default
+// (defaultIDGenerator) draws 256 bits from crypto/rand; callers that want
+// UUIDv4, KSUID, or some other scheme can supply their own via
+// NewManagerWithOptions.
+type IDGenerator func() (string, error)
+
+// defaultIDGenerator returns a cryptographically random, base64url-encoded
+// session ID backed by 256 bits of entropy from crypto/rand.
+func defaultIDGenerator() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("guardian: generate session id: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
 }
 
-var nameSpaces = make(map[string]struct{})
+func (man *Manager) newSessionID() (string, error) {
+	return man.idGenerator()
+}
+
+var (
+	nameSpacesMu sync.Mutex
+	nameSpaces   = make(map[string]struct{})
+)
 
 func ValidateNamespace(name string) error {
+	nameSpacesMu.Lock()
+	defer nameSpacesMu.Unlock()
+
 	_, ok := nameSpaces[name]
 	if ok {
-		// json.NewEncoder(os.Stdout).Encode(nameSpaces)
 		return (fmt.Errorf("namespace %s already exists", name))
 	}
-	// json.NewEncoder(os.Stdout).Encode(nameSpaces)
 	nameSpaces[name] = struct{}{}
 	return nil
 }
+
+// UnregisterNamespace frees name so it can be claimed again by a future
+// NewManager/NewManagerWithOptions call, e.g. between tests that each
+// want their own Manager under the same name.
+func UnregisterNamespace(name string) error {
+	nameSpacesMu.Lock()
+	defer nameSpacesMu.Unlock()
+
+	if _, ok := nameSpaces[name]; !ok {
+		return fmt.Errorf("namespace %s does not exist", name)
+	}
+	delete(nameSpaces, name)
+	return nil
+}
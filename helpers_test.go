@@ -0,0 +1,47 @@
+package guardian_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/1jack80/guardian"
+)
+
+// TestManager_CreateSession_UniqueIDsUnderConcurrency guards against the
+// old md5(name+time.Now().UnixNano()) generator, which could hand out the
+// same ID twice when called more than once within the same nanosecond.
+func TestManager_CreateSession_UniqueIDsUnderConcurrency(t *testing.T) {
+	store := guardian.NewInMemoryStore()
+	manager, err := guardian.NewManager("id_uniqueness_test_manager", store)
+	if err != nil {
+		t.Fatalf("unable to create session manager: %v", err)
+	}
+
+	const n = 500
+	ids := make(chan string, n)
+	var wg sync.WaitGroup
+
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			session, err := manager.CreateSession(context.Background())
+			if err != nil {
+				t.Errorf("unable to create session: %v", err)
+				return
+			}
+			ids <- session.ID
+		}()
+	}
+	wg.Wait()
+	close(ids)
+
+	seen := make(map[string]struct{}, n)
+	for id := range ids {
+		if _, dup := seen[id]; dup {
+			t.Fatalf("duplicate session id generated: %s", id)
+		}
+		seen[id] = struct{}{}
+	}
+}
@@ -1,8 +1,10 @@
 package guardian
 
 import (
+	"context"
 	"errors"
 	"sync"
+	"time"
 )
 
 // InMemoryStore is an in-memory implementation of the Storer interface.
@@ -20,7 +22,11 @@ func NewInMemoryStore() *InMemoryStore {
 }
 
 // get retrieves session data from the in-memory store.
-func (s *InMemoryStore) Get(sessionID string) (*Session, error) {
+func (s *InMemoryStore) Get(ctx context.Context, sessionID string) (*Session, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	s.lock.RLock()
 	defer s.lock.RUnlock()
 
@@ -28,11 +34,16 @@ func (s *InMemoryStore) Get(sessionID string) (*Session, error) {
 	if !ok {
 		return nil, errors.New("Session not found")
 	}
+	session.clearFresh()
 	return session, nil
 }
 
 // save saves a session into the in-memory store.
-func (s *InMemoryStore) Save(session *Session) error {
+func (s *InMemoryStore) Save(ctx context.Context, session *Session) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
@@ -41,7 +52,11 @@ func (s *InMemoryStore) Save(session *Session) error {
 }
 
 // delete deletes session data from the in-memory store.
-func (s *InMemoryStore) Delete(sessionID string) error {
+func (s *InMemoryStore) Delete(ctx context.Context, sessionID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
@@ -50,10 +65,56 @@ func (s *InMemoryStore) Delete(sessionID string) error {
 }
 
 // Update updates session data in the in-memory store.
-func (s *InMemoryStore) Update(sessionID string, newSession *Session) error {
+func (s *InMemoryStore) Update(ctx context.Context, sessionID string, newSession *Session) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
 	s.data[sessionID] = newSession
 	return nil
 }
+
+// GC removes sessions that are expired or invalidated past their idle
+// window. It is cheap enough to run inline under the store's own lock
+// since InMemoryStore never has to cross a network to do it.
+func (s *InMemoryStore) GC(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	now := time.Now()
+	for id, session := range s.data {
+		if now.After(session.ExpiryTime) {
+			delete(s.data, id)
+			continue
+		}
+		if session.Status == INVALID && now.After(session.IdleTime) {
+			delete(s.data, id)
+		}
+	}
+	return nil
+}
+
+// Reset discards every session the store holds.
+func (s *InMemoryStore) Reset(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.data = make(map[string]*Session)
+	return nil
+}
+
+// Close is a no-op: InMemoryStore holds no resources beyond its own map.
+func (s *InMemoryStore) Close(ctx context.Context) error {
+	return nil
+}
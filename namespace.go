@@ -0,0 +1,84 @@
+package guardian
+
+import (
+	"context"
+	"fmt"
+)
+
+// nsStore wraps a Storer so every key a Manager writes is scoped under its
+// own namespace, letting multiple Managers (e.g. "admin_session" and
+// "user_session") share one underlying Redis/SQL/file store without their
+// session IDs colliding.
+type nsStore struct {
+	namespace  string
+	underlying Storer
+}
+
+func (n *nsStore) key(sessionID string) string {
+	return n.namespace + ":" + sessionID
+}
+
+func (n *nsStore) Get(ctx context.Context, sessionID string) (*Session, error) {
+	session, err := n.underlying.Get(ctx, n.key(sessionID))
+	if err != nil {
+		return nil, err
+	}
+	// The underlying store may have persisted the namespaced key as part
+	// of the session (gob-based stores key entirely off Session.ID), so
+	// strip it back off before handing the session to the caller. Stores
+	// like InMemoryStore hand back the exact *Session they hold, shared
+	// across every caller that looks it up, so this returns a renamed copy
+	// via withID rather than rewriting the live object's ID: a lock around
+	// the write only stops -race from firing, it doesn't stop a concurrent
+	// writer (e.g. RenewSession) from renaming the same shared object out
+	// from under this caller between the write and the return.
+	return session.withID(func(string) string { return sessionID }), nil
+}
+
+// Save hands the underlying store a session carrying its namespaced ID.
+// It builds that via withID instead of mutating session.ID in place: the
+// caller's *Session may be the same pointer another goroutine is reading
+// concurrently (InMemoryStore.Get hands back the stored pointer itself),
+// and a direct mutate-call-restore would let that reader observe the
+// transient namespaced ID.
+func (n *nsStore) Save(ctx context.Context, session *Session) error {
+	return n.underlying.Save(ctx, session.withID(n.key))
+}
+
+func (n *nsStore) Delete(ctx context.Context, sessionID string) error {
+	return n.underlying.Delete(ctx, n.key(sessionID))
+}
+
+// Update is Save's counterpart: it passes the underlying store a
+// namespaced copy of newSession rather than mutating the shared pointer,
+// for the same reason Save does.
+func (n *nsStore) Update(ctx context.Context, sessionID string, newSession *Session) error {
+	return n.underlying.Update(ctx, n.key(sessionID), newSession.withID(n.key))
+}
+
+// GC delegates to the underlying store as-is: GC is required to be
+// idempotent and safe for concurrent managers sharing a store, so there
+// is no need to scope it per namespace.
+func (n *nsStore) GC(ctx context.Context) error {
+	return n.underlying.GC(ctx)
+}
+
+// Reset refuses to run: the underlying store has no notion of namespaces,
+// so resetting it here would also wipe every other Manager sharing it.
+// Reset the underlying store directly if that's really what's wanted.
+func (n *nsStore) Reset(ctx context.Context) error {
+	return fmt.Errorf("guardian: Reset is not supported on a namespaced store (namespace %q shares its underlying store with others)", n.namespace)
+}
+
+// Close is a no-op: the underlying store may be shared with other
+// Managers, so closing it is the caller's responsibility, not this
+// namespace wrapper's.
+func (n *nsStore) Close(ctx context.Context) error {
+	return nil
+}
+
+// namespacedStore wraps store so this Manager's keys are scoped under its
+// own name.
+func (man *Manager) namespacedStore(store Storer) Storer {
+	return &nsStore{namespace: man.name, underlying: store}
+}
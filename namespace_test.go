@@ -0,0 +1,192 @@
+package guardian_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/1jack80/guardian"
+)
+
+// TestManagers_ShareStoreWithoutCollision verifies that two Managers with
+// different namespaces can use the same Storer without one manager's
+// sessions clobbering the other's, even when the caller mints the same
+// session ID for both.
+func TestManagers_ShareStoreWithoutCollision(t *testing.T) {
+	store := guardian.NewInMemoryStore()
+
+	admin, err := guardian.NewManager("ns_admin_session", store)
+	if err != nil {
+		t.Fatalf("unable to create admin manager: %v", err)
+	}
+	defer admin.Close()
+
+	user, err := guardian.NewManager("ns_user_session", store)
+	if err != nil {
+		t.Fatalf("unable to create user manager: %v", err)
+	}
+	defer user.Close()
+
+	const sharedID = "shared-id"
+	ctx := context.Background()
+
+	if err := admin.SaveSession(ctx, &guardian.Session{ID: sharedID, Status: guardian.VALID}); err != nil {
+		t.Fatalf("admin.SaveSession: %v", err)
+	}
+	adminSession, err := admin.GetSession(ctx, sharedID)
+	if err != nil {
+		t.Fatalf("admin.GetSession: %v", err)
+	}
+	adminSession.Set("role", "admin")
+	if err := admin.SaveSession(ctx, adminSession); err != nil {
+		t.Fatalf("admin.SaveSession (update): %v", err)
+	}
+
+	if err := user.SaveSession(ctx, &guardian.Session{ID: sharedID, Status: guardian.VALID}); err != nil {
+		t.Fatalf("user.SaveSession: %v", err)
+	}
+	userSession, err := user.GetSession(ctx, sharedID)
+	if err != nil {
+		t.Fatalf("user.GetSession: %v", err)
+	}
+	userSession.Set("role", "user")
+	if err := user.SaveSession(ctx, userSession); err != nil {
+		t.Fatalf("user.SaveSession (update): %v", err)
+	}
+
+	adminSession, err = admin.GetSession(ctx, sharedID)
+	if err != nil {
+		t.Fatalf("admin.GetSession: %v", err)
+	}
+	if got := adminSession.GetString("role"); got != "admin" {
+		t.Fatalf("admin session role = %q, want %q (namespace leaked)", got, "admin")
+	}
+
+	userSession, err = user.GetSession(ctx, sharedID)
+	if err != nil {
+		t.Fatalf("user.GetSession: %v", err)
+	}
+	if got := userSession.GetString("role"); got != "user" {
+		t.Fatalf("user session role = %q, want %q (namespace leaked)", got, "user")
+	}
+
+	if adminSession.ID != sharedID || userSession.ID != sharedID {
+		t.Fatalf("session ID leaked namespace prefix: admin=%q user=%q", adminSession.ID, userSession.ID)
+	}
+}
+
+// TestManager_Close verifies Close frees the manager's namespace for reuse.
+func TestManager_Close(t *testing.T) {
+	store := guardian.NewInMemoryStore()
+
+	manager, err := guardian.NewManager("ns_close_test", store)
+	if err != nil {
+		t.Fatalf("unable to create manager: %v", err)
+	}
+	if err := manager.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	if _, err := guardian.NewManager("ns_close_test", store); err != nil {
+		t.Fatalf("expected namespace to be reusable after Close, got: %v", err)
+	}
+}
+
+// TestManager_GetSession_ConcurrentWithRenewNeverReturnsWrongID drives many
+// concurrent GetSession(oldID) calls against a session undergoing a
+// concurrent RenewSession(oldID). nsStore.Get used to strip the namespace
+// prefix by rewriting the ID field on the very *Session the underlying
+// store holds, and RenewSession renamed that same shared object - both
+// writes were individually lock-guarded, but a Get could still return the
+// object mid-rename, handing a caller who asked for oldID a Session whose
+// ID had already become the new one.
+func TestManager_GetSession_ConcurrentWithRenewNeverReturnsWrongID(t *testing.T) {
+	store := guardian.NewInMemoryStore()
+
+	manager, err := guardian.NewManager("ns_renew_race_test", store)
+	if err != nil {
+		t.Fatalf("unable to create manager: %v", err)
+	}
+	defer manager.Close()
+
+	ctx := context.Background()
+	session, err := manager.CreateSession(ctx)
+	if err != nil {
+		t.Fatalf("unable to create session: %v", err)
+	}
+	oldID := session.ID
+
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				got, err := manager.GetSession(ctx, oldID)
+				if err != nil {
+					continue // renamed/deleted already; not the bug under test
+				}
+				if got.ID != oldID {
+					t.Errorf("GetSession(%q) returned a session with ID %q", oldID, got.ID)
+					return
+				}
+			}
+		}()
+	}
+
+	if _, err := manager.RenewSession(ctx, oldID); err != nil {
+		t.Fatalf("RenewSession: %v", err)
+	}
+	close(stop)
+	wg.Wait()
+}
+
+// TestManager_Middleware_ConcurrentRequestsDoNotRaceOnSessionID drives many
+// goroutines through Middleware against one shared session. nsStore used
+// to round-trip Session.ID through its namespaced form by mutating the
+// shared *Session in place, which -race flags the moment two requests hit
+// the same session concurrently (and, worse, can leak the transient
+// namespaced ID into a cookie or a store write).
+func TestManager_Middleware_ConcurrentRequestsDoNotRaceOnSessionID(t *testing.T) {
+	const managerName = "ns_concurrent_test"
+	store := guardian.NewInMemoryStore()
+
+	manager, err := guardian.NewManager(managerName, store)
+	if err != nil {
+		t.Fatalf("unable to create manager: %v", err)
+	}
+	defer manager.Close()
+
+	ctx := context.Background()
+	session, err := manager.CreateSession(ctx)
+	if err != nil {
+		t.Fatalf("unable to create session: %v", err)
+	}
+
+	handler := manager.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s := r.Context().Value(manager.ContextKey()).(*guardian.Session)
+		s.Set("touched", true)
+	}))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.AddCookie(&http.Cookie{Name: managerName + "_session", Value: session.ID})
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+		}()
+	}
+	wg.Wait()
+}
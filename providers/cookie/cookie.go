@@ -0,0 +1,201 @@
+// Package cookie implements a guardian.Storer that keeps no plaintext
+// session state server-side: every session is AES-GCM encrypted and the
+// ciphertext itself is the thing meant to round-trip through the client.
+//
+// The existing Storer shape (Get/Save/Delete/Update by sessionID) assumes
+// a stable ID that some other store resolves server-side, so this package
+// also keeps a small in-process index from sessionID to ciphertext to
+// satisfy that shape. No session content is ever held anywhere in
+// plaintext outside of a request, and Encode/Decode are exported directly
+// for callers that want to ship the ciphertext to the client as the
+// cookie value and skip the server-side index entirely.
+package cookie
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/1jack80/guardian"
+)
+
+func init() {
+	guardian.RegisterProvider("cookie", func(config string) (guardian.Storer, error) {
+		key, err := base64.RawURLEncoding.DecodeString(config)
+		if err != nil {
+			return nil, fmt.Errorf("cookie: config must be a base64url-encoded key: %w", err)
+		}
+		return New(key)
+	})
+}
+
+// Store is a guardian.Storer backed by AES-GCM encryption rather than a
+// server-side database.
+type Store struct {
+	gcm   cipher.AEAD
+	mu    sync.RWMutex
+	index map[string][]byte // sessionID -> ciphertext, see package doc
+}
+
+// New creates a Store from a 16, 24, or 32 byte key, selecting AES-128,
+// AES-192, or AES-256 respectively.
+func New(key []byte) (*Store, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("cookie: invalid key: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("cookie: %w", err)
+	}
+	return &Store{gcm: gcm, index: make(map[string][]byte)}, nil
+}
+
+// Encode encrypts session and returns the ciphertext as a base64url
+// string suitable for use directly as a cookie value.
+func (s *Store) Encode(session *guardian.Session) (string, error) {
+	var buf strings.Builder
+	if err := gob.NewEncoder(&buf).Encode(session); err != nil {
+		return "", fmt.Errorf("cookie: encode %q: %w", session.ID, err)
+	}
+
+	nonce := make([]byte, s.gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", fmt.Errorf("cookie: nonce: %w", err)
+	}
+
+	sealed := s.gcm.Seal(nonce, nonce, []byte(buf.String()), nil)
+	return base64.RawURLEncoding.EncodeToString(sealed), nil
+}
+
+// Decode reverses Encode, rejecting tokens that fail authentication.
+func (s *Store) Decode(token string) (*guardian.Session, error) {
+	sealed, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("cookie: malformed token: %w", err)
+	}
+
+	nonceSize := s.gcm.NonceSize()
+	if len(sealed) < nonceSize {
+		return nil, errors.New("cookie: token too short")
+	}
+	nonce, ciphertext := sealed[:nonceSize], sealed[nonceSize:]
+
+	plain, err := s.gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("cookie: decrypt: %w", err)
+	}
+
+	session := &guardian.Session{}
+	if err := gob.NewDecoder(strings.NewReader(string(plain))).Decode(session); err != nil {
+		return nil, fmt.Errorf("cookie: decode: %w", err)
+	}
+	return session, nil
+}
+
+func (s *Store) Get(ctx context.Context, sessionID string) (*guardian.Session, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	ciphertext, ok := s.index[sessionID]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("cookie: session %q not found", sessionID)
+	}
+	return s.Decode(base64.RawURLEncoding.EncodeToString(ciphertext))
+}
+
+func (s *Store) Save(ctx context.Context, session *guardian.Session) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	token, err := s.Encode(session)
+	if err != nil {
+		return err
+	}
+	ciphertext, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.index[session.ID] = ciphertext
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *Store) Delete(ctx context.Context, sessionID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	delete(s.index, sessionID)
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *Store) Update(ctx context.Context, sessionID string, newSession *guardian.Session) error {
+	if err := s.Save(ctx, newSession); err != nil {
+		return err
+	}
+	if newSession.ID != sessionID {
+		s.mu.Lock()
+		delete(s.index, sessionID)
+		s.mu.Unlock()
+	}
+	return nil
+}
+
+// GC drops index entries whose ciphertext has passed its expiry. This is
+// best-effort bookkeeping for the in-process index only; it has no effect
+// on tokens already handed out to clients, which remain valid until they
+// fail decryption or the caller re-checks ExpiryTime after Decode.
+func (s *Store) GC(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for id, ciphertext := range s.index {
+		session, err := s.Decode(base64.RawURLEncoding.EncodeToString(ciphertext))
+		if err != nil || now.After(session.ExpiryTime) {
+			delete(s.index, id)
+		}
+	}
+	return nil
+}
+
+// Reset clears the in-process index. It has no effect on tokens already
+// handed out to clients, same caveat as GC.
+func (s *Store) Reset(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.index = make(map[string][]byte)
+	return nil
+}
+
+// Close is a no-op: Store holds no resources beyond its own index.
+func (s *Store) Close(ctx context.Context) error {
+	return nil
+}
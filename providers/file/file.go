@@ -0,0 +1,175 @@
+// Package file implements a guardian.Storer that persists each session as
+// its own file on disk, so sessions survive a process restart without
+// needing an external server.
+package file
+
+import (
+	"context"
+	"encoding/gob"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/1jack80/guardian"
+)
+
+func init() {
+	guardian.RegisterProvider("file", func(config string) (guardian.Storer, error) {
+		return New(config)
+	})
+}
+
+// Store is a guardian.Storer that keeps one gob-encoded file per session
+// inside dir. A process-local mutex serializes access to the directory;
+// it does not coordinate across processes sharing the same dir.
+type Store struct {
+	dir  string
+	lock sync.Mutex
+}
+
+// New creates a Store rooted at dir, creating dir if it does not exist.
+func New(dir string) (*Store, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("file: empty store directory")
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("file: create store directory %q: %w", dir, err)
+	}
+	return &Store{dir: dir}, nil
+}
+
+func (s *Store) path(sessionID string) string {
+	return filepath.Join(s.dir, sessionID+".gob")
+}
+
+func (s *Store) Get(ctx context.Context, sessionID string) (*guardian.Session, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	f, err := os.Open(s.path(sessionID))
+	if err != nil {
+		return nil, fmt.Errorf("file: get %q: %w", sessionID, err)
+	}
+	defer f.Close()
+
+	session := &guardian.Session{}
+	if err := gob.NewDecoder(f).Decode(session); err != nil {
+		return nil, fmt.Errorf("file: decode %q: %w", sessionID, err)
+	}
+	return session, nil
+}
+
+func (s *Store) Save(ctx context.Context, session *guardian.Session) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	f, err := os.OpenFile(s.path(session.ID), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("file: save %q: %w", session.ID, err)
+	}
+	defer f.Close()
+
+	if err := gob.NewEncoder(f).Encode(session); err != nil {
+		return fmt.Errorf("file: encode %q: %w", session.ID, err)
+	}
+	return nil
+}
+
+func (s *Store) Delete(ctx context.Context, sessionID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if err := os.Remove(s.path(sessionID)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("file: delete %q: %w", sessionID, err)
+	}
+	return nil
+}
+
+func (s *Store) Update(ctx context.Context, sessionID string, newSession *guardian.Session) error {
+	return s.Save(ctx, newSession)
+}
+
+// GC walks the store directory and removes any session file that has
+// expired or has gone invalid past its idle window.
+func (s *Store) GC(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return fmt.Errorf("file: gc: %w", err)
+	}
+
+	now := time.Now()
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		path := filepath.Join(s.dir, entry.Name())
+
+		f, err := os.Open(path)
+		if err != nil {
+			continue
+		}
+		session := &guardian.Session{}
+		err = gob.NewDecoder(f).Decode(session)
+		f.Close()
+		if err != nil {
+			continue
+		}
+
+		expired := now.After(session.ExpiryTime)
+		idleInvalid := session.Status == guardian.INVALID && now.After(session.IdleTime)
+		if expired || idleInvalid {
+			os.Remove(path)
+		}
+	}
+	return nil
+}
+
+// Reset removes every session file in the store directory.
+func (s *Store) Reset(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return fmt.Errorf("file: reset: %w", err)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		if err := os.Remove(filepath.Join(s.dir, entry.Name())); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("file: reset: %w", err)
+		}
+	}
+	return nil
+}
+
+// Close is a no-op: Store holds no file handles open between calls.
+func (s *Store) Close(ctx context.Context) error {
+	return nil
+}
@@ -0,0 +1,147 @@
+package file_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/1jack80/guardian"
+	"github.com/1jack80/guardian/providers/file"
+)
+
+// newTestStore returns a Store rooted at a fresh temp directory that is
+// cleaned up automatically when the test ends.
+func newTestStore(t *testing.T) *file.Store {
+	t.Helper()
+
+	store, err := file.New(t.TempDir())
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return store
+}
+
+// TestStore_SaveGet verifies a saved session round-trips through Get with
+// its data intact.
+func TestStore_SaveGet(t *testing.T) {
+	store := newTestStore(t)
+	ctx := context.Background()
+
+	session := &guardian.Session{
+		ID:         "one",
+		Status:     guardian.VALID,
+		ExpiryTime: time.Now().Add(time.Hour),
+	}
+	session.Set("role", "admin")
+
+	if err := store.Save(ctx, session); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	got, err := store.Get(ctx, "one")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.ID != "one" {
+		t.Fatalf("Get returned ID %q, want %q", got.ID, "one")
+	}
+	if got.GetString("role") != "admin" {
+		t.Fatalf("Get returned role %q, want %q", got.GetString("role"), "admin")
+	}
+}
+
+// TestStore_Update verifies Update overwrites the stored session in place.
+func TestStore_Update(t *testing.T) {
+	store := newTestStore(t)
+	ctx := context.Background()
+
+	session := &guardian.Session{ID: "one", ExpiryTime: time.Now().Add(time.Hour)}
+	if err := store.Save(ctx, session); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	session.Set("visits", 1)
+	if err := store.Update(ctx, "one", session); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	got, err := store.Get(ctx, "one")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.GetInt("visits") != 1 {
+		t.Fatalf("Get returned visits %d, want 1", got.GetInt("visits"))
+	}
+}
+
+// TestStore_Delete verifies a deleted session is no longer retrievable.
+func TestStore_Delete(t *testing.T) {
+	store := newTestStore(t)
+	ctx := context.Background()
+
+	if err := store.Save(ctx, &guardian.Session{ID: "one", ExpiryTime: time.Now().Add(time.Hour)}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if err := store.Delete(ctx, "one"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := store.Get(ctx, "one"); err == nil {
+		t.Fatal("expected session to be gone after Delete")
+	}
+}
+
+// TestStore_GC verifies GC removes an expired session file and leaves
+// unexpired ones alone, including an invalidated one whose idle window has
+// not yet passed.
+func TestStore_GC(t *testing.T) {
+	store := newTestStore(t)
+	ctx := context.Background()
+
+	expired := &guardian.Session{ID: "expired", ExpiryTime: time.Now().Add(-time.Hour)}
+	invalidButLive := &guardian.Session{ID: "invalid_but_live", Status: guardian.INVALID, IdleTime: time.Now().Add(time.Hour), ExpiryTime: time.Now().Add(time.Hour)}
+	live := &guardian.Session{ID: "live", Status: guardian.VALID, IdleTime: time.Now().Add(time.Hour), ExpiryTime: time.Now().Add(time.Hour)}
+
+	for _, s := range []*guardian.Session{expired, invalidButLive, live} {
+		if err := store.Save(ctx, s); err != nil {
+			t.Fatalf("Save(%q): %v", s.ID, err)
+		}
+	}
+
+	if err := store.GC(ctx); err != nil {
+		t.Fatalf("GC: %v", err)
+	}
+
+	if _, err := store.Get(ctx, "expired"); err == nil {
+		t.Fatal("expected expired session to be swept by GC")
+	}
+	if _, err := store.Get(ctx, "invalid_but_live"); err != nil {
+		t.Fatalf("invalidated-but-unexpired session should survive GC, got: %v", err)
+	}
+	if _, err := store.Get(ctx, "live"); err != nil {
+		t.Fatalf("live session should survive GC, got: %v", err)
+	}
+}
+
+// TestStore_Reset verifies Reset removes every session file in the store
+// directory.
+func TestStore_Reset(t *testing.T) {
+	store := newTestStore(t)
+	ctx := context.Background()
+
+	if err := store.Save(ctx, &guardian.Session{ID: "one", ExpiryTime: time.Now().Add(time.Hour)}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if err := store.Reset(ctx); err != nil {
+		t.Fatalf("Reset: %v", err)
+	}
+	if _, err := store.Get(ctx, "one"); err == nil {
+		t.Fatal("expected session to be gone after Reset")
+	}
+}
+
+// TestNew_RejectsEmptyDir verifies New refuses an empty store directory.
+func TestNew_RejectsEmptyDir(t *testing.T) {
+	if _, err := file.New(""); err == nil {
+		t.Fatal("expected New to reject an empty directory")
+	}
+}
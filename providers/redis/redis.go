@@ -0,0 +1,106 @@
+// Package redis implements a guardian.Storer backed by Redis, suitable for
+// horizontally scaled deployments where the session store must be shared
+// across processes.
+package redis
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"fmt"
+	"time"
+
+	"github.com/1jack80/guardian"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+func init() {
+	guardian.RegisterProvider("redis", func(config string) (guardian.Storer, error) {
+		opts, err := goredis.ParseURL(config)
+		if err != nil {
+			return nil, fmt.Errorf("redis: invalid config %q: %w", config, err)
+		}
+		return New(goredis.NewClient(opts)), nil
+	})
+}
+
+// Store is a guardian.Storer backed by Redis. Session expiry is enforced
+// by Redis itself via key TTLs, so GC is a no-op. Invalidated sessions are
+// not removed early: their keys stay until the TTL set from ExpiryTime runs
+// out, and Get returns them as stored.
+type Store struct {
+	client *goredis.Client
+}
+
+// New wraps an existing *goredis.Client as a guardian.Storer. Callers that
+// already manage a shared Redis connection pool should use this directly;
+// config-string based construction goes through the "redis" provider name
+// registered by this package's init().
+func New(client *goredis.Client) *Store {
+	return &Store{client: client}
+}
+
+func (s *Store) Get(ctx context.Context, sessionID string) (*guardian.Session, error) {
+	raw, err := s.client.Get(ctx, sessionID).Bytes()
+	if err != nil {
+		return nil, fmt.Errorf("redis: get %q: %w", sessionID, err)
+	}
+
+	session := &guardian.Session{}
+	if err := gob.NewDecoder(bytes.NewReader(raw)).Decode(session); err != nil {
+		return nil, fmt.Errorf("redis: decode %q: %w", sessionID, err)
+	}
+	return session, nil
+}
+
+func (s *Store) Save(ctx context.Context, session *guardian.Session) error {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(session); err != nil {
+		return fmt.Errorf("redis: encode %q: %w", session.ID, err)
+	}
+
+	ttl := time.Until(session.ExpiryTime)
+	if ttl <= 0 {
+		return fmt.Errorf("redis: save %q: already expired", session.ID)
+	}
+	if err := s.client.Set(ctx, session.ID, buf.Bytes(), ttl).Err(); err != nil {
+		return fmt.Errorf("redis: save %q: %w", session.ID, err)
+	}
+	return nil
+}
+
+func (s *Store) Delete(ctx context.Context, sessionID string) error {
+	if err := s.client.Del(ctx, sessionID).Err(); err != nil {
+		return fmt.Errorf("redis: delete %q: %w", sessionID, err)
+	}
+	return nil
+}
+
+func (s *Store) Update(ctx context.Context, sessionID string, newSession *guardian.Session) error {
+	return s.Save(ctx, newSession)
+}
+
+// GC is a no-op: Redis already expires keys via TTL once ExpiryTime has
+// passed. Invalidated sessions are left in place until then.
+func (s *Store) GC(ctx context.Context) error {
+	return nil
+}
+
+// Reset drops every key in the Redis database the client is connected to.
+// Since Redis has no notion of guardian's own key namespace, this assumes
+// the database is dedicated to this Store; callers sharing a database with
+// other data should flush it themselves instead.
+func (s *Store) Reset(ctx context.Context) error {
+	if err := s.client.FlushDB(ctx).Err(); err != nil {
+		return fmt.Errorf("redis: reset: %w", err)
+	}
+	return nil
+}
+
+// Close closes the underlying Redis client connection.
+func (s *Store) Close(ctx context.Context) error {
+	if err := s.client.Close(); err != nil {
+		return fmt.Errorf("redis: close: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+// Package sql implements a guardian.Storer on top of database/sql, targeting
+// SQLite specifically: every query here uses `?` placeholders and SQLite's
+// upsert syntax (`INSERT ... ON CONFLICT ... DO UPDATE`), and Schema's BLOB
+// column type is SQLite's, not Postgres's BYTEA. Wiring this package up to
+// a Postgres or MySQL driver will fail - a different dialect needs its own
+// placeholder style and upsert syntax, which this package does not build.
+package sql
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/gob"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/1jack80/guardian"
+)
+
+// tableNameRE matches the identifiers New accepts as a table name: letters,
+// digits, and underscores, not starting with a digit. Every query in this
+// package builds its SQL with fmt.Sprintf("... %s ...", s.table), so the
+// table name has to be validated once here rather than escaped (there's no
+// placeholder syntax for identifiers) on every call.
+var tableNameRE = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// Store is a guardian.Storer backed by a SQLite table. The table is
+// created automatically by New if it does not already exist; see Schema
+// for its layout.
+type Store struct {
+	db    *sql.DB
+	table string
+}
+
+// Schema is the table layout New creates its table with, %s standing in
+// for the table name New was given.
+const Schema = `
+CREATE TABLE IF NOT EXISTS %s (
+	id          VARCHAR(255) PRIMARY KEY,
+	data        BLOB NOT NULL,
+	expiry_time TIMESTAMP NOT NULL
+)`
+
+func init() {
+	guardian.RegisterProvider("sql", func(config string) (guardian.Storer, error) {
+		// config is "driver|dataSourceName", e.g. "sqlite3|file:sessions.db".
+		driver, dsn, err := splitConfig(config)
+		if err != nil {
+			return nil, err
+		}
+		db, err := sql.Open(driver, dsn)
+		if err != nil {
+			return nil, fmt.Errorf("sql: open %q: %w", driver, err)
+		}
+		return New(db, "guardian_sessions")
+	})
+}
+
+func splitConfig(config string) (driver, dsn string, err error) {
+	for i := 0; i < len(config); i++ {
+		if config[i] == '|' {
+			return config[:i], config[i+1:], nil
+		}
+	}
+	return "", "", fmt.Errorf("sql: config must be \"driver|dsn\", got %q", config)
+}
+
+// New wraps an existing *sql.DB as a guardian.Storer, storing sessions in
+// tableName (see Schema). The table is created if it does not exist.
+//
+// tableName is spliced directly into every query this Store builds
+// (database/sql has no placeholder syntax for identifiers), so it's
+// validated here against tableNameRE rather than on every call.
+func New(db *sql.DB, tableName string) (*Store, error) {
+	if !tableNameRE.MatchString(tableName) {
+		return nil, fmt.Errorf("sql: invalid table name %q: must match %s", tableName, tableNameRE)
+	}
+
+	s := &Store{db: db, table: tableName}
+	if _, err := db.Exec(fmt.Sprintf(Schema, tableName)); err != nil {
+		return nil, fmt.Errorf("sql: create table: %w", err)
+	}
+	return s, nil
+}
+
+func (s *Store) Get(ctx context.Context, sessionID string) (*guardian.Session, error) {
+	row := s.db.QueryRowContext(ctx, fmt.Sprintf("SELECT data FROM %s WHERE id = ?", s.table), sessionID)
+
+	var data []byte
+	if err := row.Scan(&data); err != nil {
+		return nil, fmt.Errorf("sql: get %q: %w", sessionID, err)
+	}
+
+	session := &guardian.Session{}
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(session); err != nil {
+		return nil, fmt.Errorf("sql: decode %q: %w", sessionID, err)
+	}
+	return session, nil
+}
+
+func (s *Store) Save(ctx context.Context, session *guardian.Session) error {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(session); err != nil {
+		return fmt.Errorf("sql: encode %q: %w", session.ID, err)
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO %s (id, data, expiry_time) VALUES (?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET data = excluded.data, expiry_time = excluded.expiry_time`, s.table)
+	if _, err := s.db.ExecContext(ctx, query, session.ID, buf.Bytes(), session.ExpiryTime); err != nil {
+		return fmt.Errorf("sql: save %q: %w", session.ID, err)
+	}
+	return nil
+}
+
+func (s *Store) Delete(ctx context.Context, sessionID string) error {
+	if _, err := s.db.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE id = ?", s.table), sessionID); err != nil {
+		return fmt.Errorf("sql: delete %q: %w", sessionID, err)
+	}
+	return nil
+}
+
+func (s *Store) Update(ctx context.Context, sessionID string, newSession *guardian.Session) error {
+	return s.Save(ctx, newSession)
+}
+
+// GC deletes rows whose expiry_time has passed. It does not look at a
+// session's Status or IdleTime: an invalidated session whose idle window
+// has passed stays in the table until its expiry_time passes too, unlike
+// InMemoryStore.GC, which removes it as soon as its idle window is over.
+func (s *Store) GC(ctx context.Context) error {
+	if _, err := s.db.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE expiry_time < ?", s.table), time.Now()); err != nil {
+		return fmt.Errorf("sql: gc: %w", err)
+	}
+	return nil
+}
+
+// Reset deletes every row in the session table.
+func (s *Store) Reset(ctx context.Context) error {
+	if _, err := s.db.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s", s.table)); err != nil {
+		return fmt.Errorf("sql: reset: %w", err)
+	}
+	return nil
+}
+
+// Close closes the underlying *sql.DB.
+func (s *Store) Close(ctx context.Context) error {
+	if err := s.db.Close(); err != nil {
+		return fmt.Errorf("sql: close: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package sql_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	_ "modernc.org/sqlite"
+
+	"github.com/1jack80/guardian"
+	guardiansql "github.com/1jack80/guardian/providers/sql"
+)
+
+// newTestStore returns a Store backed by a fresh in-memory SQLite database,
+// so each test gets its own isolated table without touching disk.
+func newTestStore(t *testing.T) *guardiansql.Store {
+	t.Helper()
+
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	store, err := guardiansql.New(db, "guardian_sessions")
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return store
+}
+
+// TestStore_SaveGet verifies a saved session round-trips through Get with
+// its data intact.
+func TestStore_SaveGet(t *testing.T) {
+	store := newTestStore(t)
+	ctx := context.Background()
+
+	session := &guardian.Session{
+		ID:         "one",
+		Status:     guardian.VALID,
+		ExpiryTime: time.Now().Add(time.Hour),
+	}
+	session.Set("role", "admin")
+
+	if err := store.Save(ctx, session); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	got, err := store.Get(ctx, "one")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.ID != "one" {
+		t.Fatalf("Get returned ID %q, want %q", got.ID, "one")
+	}
+	if got.GetString("role") != "admin" {
+		t.Fatalf("Get returned role %q, want %q", got.GetString("role"), "admin")
+	}
+}
+
+// TestStore_Update verifies Update overwrites the stored session in place.
+func TestStore_Update(t *testing.T) {
+	store := newTestStore(t)
+	ctx := context.Background()
+
+	session := &guardian.Session{ID: "one", ExpiryTime: time.Now().Add(time.Hour)}
+	if err := store.Save(ctx, session); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	session.Set("visits", 1)
+	if err := store.Update(ctx, "one", session); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	got, err := store.Get(ctx, "one")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.GetInt("visits") != 1 {
+		t.Fatalf("Get returned visits %d, want 1", got.GetInt("visits"))
+	}
+}
+
+// TestStore_Delete verifies a deleted session is no longer retrievable.
+func TestStore_Delete(t *testing.T) {
+	store := newTestStore(t)
+	ctx := context.Background()
+
+	if err := store.Save(ctx, &guardian.Session{ID: "one", ExpiryTime: time.Now().Add(time.Hour)}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if err := store.Delete(ctx, "one"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := store.Get(ctx, "one"); err == nil {
+		t.Fatal("expected session to be gone after Delete")
+	}
+}
+
+// TestStore_GC verifies GC removes only expired rows, leaving unexpired
+// ones (including invalidated-but-not-yet-expired ones) alone.
+func TestStore_GC(t *testing.T) {
+	store := newTestStore(t)
+	ctx := context.Background()
+
+	expired := &guardian.Session{ID: "expired", ExpiryTime: time.Now().Add(-time.Hour)}
+	invalidButLive := &guardian.Session{ID: "invalid_but_live", Status: guardian.INVALID, IdleTime: time.Now().Add(time.Hour), ExpiryTime: time.Now().Add(time.Hour)}
+	live := &guardian.Session{ID: "live", Status: guardian.VALID, IdleTime: time.Now().Add(time.Hour), ExpiryTime: time.Now().Add(time.Hour)}
+
+	for _, s := range []*guardian.Session{expired, invalidButLive, live} {
+		if err := store.Save(ctx, s); err != nil {
+			t.Fatalf("Save(%q): %v", s.ID, err)
+		}
+	}
+
+	if err := store.GC(ctx); err != nil {
+		t.Fatalf("GC: %v", err)
+	}
+
+	if _, err := store.Get(ctx, "expired"); err == nil {
+		t.Fatal("expected expired session to be swept by GC")
+	}
+	if _, err := store.Get(ctx, "invalid_but_live"); err != nil {
+		t.Fatalf("invalidated-but-unexpired session should survive GC, got: %v", err)
+	}
+	if _, err := store.Get(ctx, "live"); err != nil {
+		t.Fatalf("live session should survive GC, got: %v", err)
+	}
+}
+
+// TestStore_Reset verifies Reset clears every row in the session table.
+func TestStore_Reset(t *testing.T) {
+	store := newTestStore(t)
+	ctx := context.Background()
+
+	if err := store.Save(ctx, &guardian.Session{ID: "one", ExpiryTime: time.Now().Add(time.Hour)}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if err := store.Reset(ctx); err != nil {
+		t.Fatalf("Reset: %v", err)
+	}
+	if _, err := store.Get(ctx, "one"); err == nil {
+		t.Fatal("expected session to be gone after Reset")
+	}
+}
+
+// TestNew_RejectsInvalidTableName verifies New refuses a table name that
+// isn't a safe SQL identifier instead of splicing it into a query.
+func TestNew_RejectsInvalidTableName(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	defer db.Close()
+
+	if _, err := guardiansql.New(db, "sessions; DROP TABLE users"); err == nil {
+		t.Fatal("expected New to reject an invalid table name")
+	}
+}
@@ -0,0 +1,53 @@
+package guardian
+
+import (
+	"fmt"
+	"sync"
+)
+
+// ProviderFactory builds a Storer from a provider-specific config string,
+// e.g. a DSN, a file path, or a "key1=val1;key2=val2" option list. Each
+// provider documents its own config format.
+type ProviderFactory func(config string) (Storer, error)
+
+var (
+	providersMu sync.Mutex
+	providers   = make(map[string]ProviderFactory)
+)
+
+// RegisterProvider makes a Storer backend available under name so it can
+// be selected later with NewManagerWithProvider. Providers typically call
+// this from an init() function, mirroring how database/sql drivers
+// register themselves. RegisterProvider panics if a provider with the
+// same name is registered twice, or factory is nil.
+func RegisterProvider(name string, factory ProviderFactory) {
+	providersMu.Lock()
+	defer providersMu.Unlock()
+
+	if factory == nil {
+		panic("guardian: RegisterProvider factory is nil for " + name)
+	}
+	if _, dup := providers[name]; dup {
+		panic("guardian: RegisterProvider called twice for provider " + name)
+	}
+	providers[name] = factory
+}
+
+// NewManagerWithProvider builds the Storer for providerName using its
+// registered factory and config, then constructs a Manager around it the
+// same way NewManager does.
+func NewManagerWithProvider(name, providerName, config string) (Manager, error) {
+	providersMu.Lock()
+	factory, ok := providers[providerName]
+	providersMu.Unlock()
+	if !ok {
+		return Manager{}, fmt.Errorf("guardian: unknown provider %q", providerName)
+	}
+
+	store, err := factory(config)
+	if err != nil {
+		return Manager{}, fmt.Errorf("guardian: provider %q: %w", providerName, err)
+	}
+
+	return NewManager(name, store)
+}
@@ -3,7 +3,9 @@ package guardian
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
+	"sync"
 	"time"
 )
 
@@ -12,12 +14,190 @@ const (
 	VALID
 )
 
+// Session holds per-user state behind a mutex so concurrent handlers (or
+// the middleware racing a handler) can't corrupt it. Use Set/Get/Delete/
+// Flush rather than reaching for the underlying map directly - there is
+// no exported map to reach for.
 type Session struct {
 	ID         string
-	Data       map[string]interface{}
 	Status     int
 	IdleTime   time.Time
 	ExpiryTime time.Time
+
+	mu    sync.RWMutex
+	data  map[string]interface{}
+	fresh bool
+	dirty bool
+}
+
+// Set stores val under key, guarded by the session's own lock.
+func (s *Session) Set(key string, val any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.data == nil {
+		s.data = make(map[string]interface{})
+	}
+	s.data[key] = val
+	s.dirty = true
+}
+
+// Get returns the value stored under key, or nil if it isn't set.
+func (s *Session) Get(key string) any {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.data[key]
+}
+
+// GetString returns the value under key as a string, or "" if it isn't
+// set or isn't a string.
+func (s *Session) GetString(key string) string {
+	val, _ := s.Get(key).(string)
+	return val
+}
+
+// GetInt returns the value under key as an int, or 0 if it isn't set or
+// isn't an int.
+func (s *Session) GetInt(key string) int {
+	val, _ := s.Get(key).(int)
+	return val
+}
+
+// GetBool returns the value under key as a bool, or false if it isn't set
+// or isn't a bool.
+func (s *Session) GetBool(key string) bool {
+	val, _ := s.Get(key).(bool)
+	return val
+}
+
+// Delete removes key from the session.
+func (s *Session) Delete(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.data, key)
+	s.dirty = true
+}
+
+// Flush clears every key from the session.
+func (s *Session) Flush() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data = make(map[string]interface{})
+	s.dirty = true
+}
+
+// Fresh reports whether this Session was just created by CreateSession
+// during the current request, as opposed to loaded from the store.
+func (s *Session) Fresh() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.fresh
+}
+
+// isDirty reports whether the session has been mutated since it was last
+// committed to the store.
+func (s *Session) isDirty() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.dirty
+}
+
+// clearFresh marks the session as no longer fresh. Stores call this from
+// Get, since a session handed back by a lookup is by definition not one
+// "just created" by CreateSession, even if Get happens to return the
+// exact pointer CreateSession produced (as InMemoryStore does).
+func (s *Session) clearFresh() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.fresh = false
+}
+
+// status returns the session's current Status, guarded by the session's
+// lock.
+func (s *Session) status() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.Status
+}
+
+// expiryTime returns the session's current ExpiryTime, guarded by the
+// session's lock.
+func (s *Session) expiryTime() time.Time {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.ExpiryTime
+}
+
+// idleTime returns the session's current IdleTime, guarded by the
+// session's lock.
+func (s *Session) idleTime() time.Time {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.IdleTime
+}
+
+// setID sets the session's ID, guarded by the session's lock. The same
+// *Session pointer can be shared across callers (InMemoryStore hands back
+// the exact pointer it stores), so rewriting ID - as RenewSession needs
+// to - has to go through the lock like every other
+// field GobEncode/GobDecode already guard.
+func (s *Session) setID(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.ID = id
+}
+
+// withID returns a new *Session equal to s except its ID is replaced by
+// transform(s.ID). Unlike setID, it never touches s itself: nsStore.Save
+// and nsStore.Update need to round-trip a session's ID through its
+// namespaced form to hand to the underlying store, and mutating the
+// shared *Session in place - even temporarily - would let a concurrent
+// reader of the same pointer observe the transient namespaced ID.
+//
+// data is copied element-by-element rather than by map reference: a store
+// that replaces its stored pointer on every Update (as InMemoryStore does) can
+// otherwise end up with two live *Session generations - one still held by
+// an in-flight caller, one just returned by a concurrent Get - backed by
+// the identical map and guarded by two different mutexes, which races the
+// moment both are mutated through Set/Delete/Flush.
+func (s *Session) withID(transform func(id string) string) *Session {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	data := make(map[string]interface{}, len(s.data))
+	for k, v := range s.data {
+		data[k] = v
+	}
+
+	return &Session{
+		ID:         transform(s.ID),
+		Status:     s.Status,
+		IdleTime:   s.IdleTime,
+		ExpiryTime: s.ExpiryTime,
+		data:       data,
+		fresh:      s.fresh,
+		dirty:      s.dirty,
+	}
+}
+
+// invalidate marks the session INVALID and backdates IdleTime so it reads
+// as already idle, guarded by the session's lock.
+func (s *Session) invalidate(idleTimeout time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.Status = INVALID
+	s.IdleTime = time.Now().Add(-idleTimeout)
 }
 
 type contextKey string
@@ -29,63 +209,114 @@ type Manager struct {
 	cookieName    string
 	idleTimeout   time.Duration
 	expiryTimeout time.Duration
+	idGenerator   IDGenerator
+}
+
+// ManagerOptions customizes a Manager beyond NewManager's defaults. Zero
+// values fall back to the same defaults NewManager uses.
+type ManagerOptions struct {
+	IdleTimeout   time.Duration
+	ExpiryTimeout time.Duration
+	IDGenerator   IDGenerator
 }
 
 func NewManager(name string, store Storer) (Manager, error) {
+	return NewManagerWithOptions(name, store, ManagerOptions{})
+}
+
+// NewManagerWithOptions is like NewManager but lets callers override the
+// idle/expiry timeouts and, notably, the session ID generator - useful for
+// plugging in UUIDv4, KSUID, or another scheme in place of the default
+// crypto/rand-backed generator.
+func NewManagerWithOptions(name string, store Storer, opts ManagerOptions) (Manager, error) {
 	err := ValidateNamespace(name)
 	if err != nil {
 		return Manager{}, err
 	}
-	return Manager{
+
+	idleTimeout := opts.IdleTimeout
+	if idleTimeout == 0 {
+		idleTimeout = time.Minute * 3
+	}
+	expiryTimeout := opts.ExpiryTimeout
+	if expiryTimeout == 0 {
+		expiryTimeout = time.Hour * 2
+	}
+	idGenerator := opts.IDGenerator
+	if idGenerator == nil {
+		idGenerator = defaultIDGenerator
+	}
+
+	man := Manager{
 		name:          name,
-		store:         store,
 		cookieName:    name + "_session",
 		contextKey:    newContextKey(name),
-		idleTimeout:   time.Minute * 3,
-		expiryTimeout: time.Hour * 2,
-	}, err
+		idleTimeout:   idleTimeout,
+		expiryTimeout: expiryTimeout,
+		idGenerator:   idGenerator,
+	}
+	man.store = man.namespacedStore(store)
+	return man, nil
+}
+
+// Close releases the manager's namespace, allowing a new Manager to reuse
+// name. It does not touch the underlying store, which may still be
+// shared with other managers.
+func (man *Manager) Close() error {
+	return UnregisterNamespace(man.name)
 }
 
 // create a new session and add it to the store.
-func (man *Manager) CreateSession() Session {
-	newSession := Session{
-		ID:         man.newSessionID(),
-		Data:       make(map[string]interface{}),
+func (man *Manager) CreateSession(ctx context.Context) (*Session, error) {
+	id, err := man.newSessionID()
+	if err != nil {
+		return nil, fmt.Errorf("unable to create session: %w", err)
+	}
+
+	newSession := &Session{
+		ID:         id,
 		Status:     VALID,
 		IdleTime:   time.Now().Add(man.idleTimeout),
 		ExpiryTime: time.Now().Add(man.expiryTimeout),
+		fresh:      true,
 	}
-	man.store.Save(newSession)
-	return newSession
+	if err := man.store.Save(ctx, newSession); err != nil {
+		return nil, err
+	}
+	return newSession, nil
 }
 
-func (man *Manager) SaveSession(sessonInstance Session) error {
-	return man.store.Save(sessonInstance)
+func (man *Manager) SaveSession(ctx context.Context, session *Session) error {
+	return man.store.Save(ctx, session)
 }
 
-func (man *Manager) GetSession(sessionID string) (Session, error) {
-	return man.store.Get(sessionID)
+func (man *Manager) GetSession(ctx context.Context, sessionID string) (*Session, error) {
+	return man.store.Get(ctx, sessionID)
 }
 
-func (man *Manager) UpdateSession(sessionID string, sessionInstance Session) error {
-	return man.store.Update(sessionID, sessionInstance)
+func (man *Manager) UpdateSession(ctx context.Context, sessionID string, session *Session) error {
+	return man.store.Update(ctx, sessionID, session)
 }
 
 // change the session id of the session but maintain the data therein
-func (man *Manager) RenewSession(sessionID string) (Session, error) {
-	newID := man.newSessionID()
-	oldSession, err := man.store.Get(sessionID)
+func (man *Manager) RenewSession(ctx context.Context, sessionID string) (*Session, error) {
+	newID, err := man.newSessionID()
+	if err != nil {
+		return nil, fmt.Errorf("unable to renew session: %w", err)
+	}
+
+	oldSession, err := man.store.Get(ctx, sessionID)
 	if err != nil {
-		return Session{}, err
+		return nil, err
 	}
-	oldSession.ID = newID
+	oldSession.setID(newID)
 
-	err = man.store.Save(oldSession)
+	err = man.store.Save(ctx, oldSession)
 	if err != nil {
-		return Session{}, err
+		return nil, err
 	}
 
-	err = man.store.Delete(sessionID)
+	err = man.store.Delete(ctx, sessionID)
 	if err != nil {
 		return oldSession, errors.New("unable to delete old session from store; although new session ID was saved successfully")
 	}
@@ -94,31 +325,30 @@ func (man *Manager) RenewSession(sessionID string) (Session, error) {
 
 // mark the session as invalid but keep it around until the session expiry time elapses
 // by this time the associated cookie should have also expired then the session can be deleted
-func (man *Manager) InvalidateSession(sessionID string) error {
-	session, err := man.store.Get(sessionID)
+func (man *Manager) InvalidateSession(ctx context.Context, sessionID string) error {
+	session, err := man.store.Get(ctx, sessionID)
 	if err != nil {
 		return err
 	}
-	session.Status = INVALID
-	session.IdleTime = time.Now().Add(-man.idleTimeout)
-	return man.store.Update(sessionID, session)
+	session.invalidate(man.idleTimeout)
+	return man.store.Update(ctx, sessionID, session)
 }
 
 // a wrapper over the delete method in the store
-func (man *Manager) DeleteSession(sessionID string) error {
-	return man.store.Delete(sessionID)
+func (man *Manager) DeleteSession(ctx context.Context, sessionID string) error {
+	return man.store.Delete(ctx, sessionID)
 }
 
 // creates and returns a new cookie using a session
-func (man *Manager) CeateCookie(sessionID string) (http.Cookie, error) {
-	session, err := man.store.Get(sessionID)
+func (man *Manager) CeateCookie(ctx context.Context, sessionID string) (http.Cookie, error) {
+	session, err := man.store.Get(ctx, sessionID)
 	if err != nil {
 		return http.Cookie{}, err
 	}
 	return http.Cookie{
 		Name:    man.cookieName,
 		Value:   sessionID,
-		Expires: session.ExpiryTime,
+		Expires: session.expiryTime(),
 		// Secure:   true, // https traffic only
 		HttpOnly: true,
 		SameSite: http.SameSiteLaxMode,
@@ -126,11 +356,42 @@ func (man *Manager) CeateCookie(sessionID string) (http.Cookie, error) {
 }
 
 // fill the request context with the given session and returns the updated request
-func (man *Manager) PopulateRequestContext(r *http.Request, session Session) *http.Request {
+func (man *Manager) PopulateRequestContext(r *http.Request, session *Session) *http.Request {
 	ctx := context.WithValue(r.Context(), man.contextKey, session)
 	return r.WithContext(ctx)
 }
 
+// Release commits session back to the store if it has been mutated since
+// it was loaded, extending its idle time along the way. This replaces the
+// old "refetch after handler" dance: the middleware already holds the
+// authoritative *Session a handler may have mutated through Set/Delete/
+// Flush, so there is nothing left to refetch - only a dirty check.
+//
+// The idle-time bump itself does not count as a mutation: it happens on
+// every request for a VALID session regardless of whether the handler
+// touched the session's data, so folding it into dirty would make dirty
+// true unconditionally and defeat the point of tracking it.
+func (man *Manager) Release(ctx context.Context, sessionID string, session *Session) error {
+	session.mu.Lock()
+	if session.Status == VALID {
+		session.IdleTime = time.Now().Add(man.idleTimeout)
+	}
+	session.mu.Unlock()
+
+	if !session.isDirty() {
+		return nil
+	}
+
+	if err := man.store.Update(ctx, sessionID, session); err != nil {
+		return err
+	}
+
+	session.mu.Lock()
+	session.dirty = false
+	session.mu.Unlock()
+	return nil
+}
+
 // populates the contexts of new requests with the sessions to which the request cookie
 // points. The middleware also extends the session idle times after each request
 func (man *Manager) Middleware(next http.Handler) http.Handler {
@@ -141,19 +402,21 @@ func (man *Manager) Middleware(next http.Handler) http.Handler {
 			return
 		}
 
+		ctx := r.Context()
+
 		sessionID := cookie.Value
-		session, err := man.store.Get(sessionID)
+		session, err := man.store.Get(ctx, sessionID)
 		if err != nil {
 			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
 			return
 		}
 
 		// watch timeouts
-		if time.Now().After(session.ExpiryTime) {
+		if time.Now().After(session.expiryTime()) {
 			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
 			return
 		}
-		if time.Now().After(session.IdleTime) {
+		if time.Now().After(session.idleTime()) {
 			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
 			return
 		}
@@ -162,21 +425,12 @@ func (man *Manager) Middleware(next http.Handler) http.Handler {
 
 		next.ServeHTTP(w, r)
 
-		// session must be refetched from store in case other handlers down the chain
-		// tampered with the current one.
-		session, err = man.store.Get(sessionID)
-		if err != nil {
-			// http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		if err := man.Release(ctx, sessionID, session); err != nil {
 			// log err
 			return
 		}
 
-		if session.Status == VALID {
-			session.IdleTime = time.Now().Add(man.idleTimeout)
-			man.store.Update(sessionID, session)
-		}
-
-		newCookie, err := man.CeateCookie(sessionID)
+		newCookie, err := man.CeateCookie(ctx, sessionID)
 		if err != nil {
 			// http.Error(w, "Unable to respond with proper cookie: "+
 			// http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
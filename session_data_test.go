@@ -0,0 +1,124 @@
+package guardian_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/1jack80/guardian"
+)
+
+// releaseTrackingStore wraps InMemoryStore to count Update calls, so tests
+// can assert Release skipped (or made) a store write without relying on
+// InMemoryStore's internals.
+type releaseTrackingStore struct {
+	*guardian.InMemoryStore
+	updates int
+}
+
+func (s *releaseTrackingStore) Update(ctx context.Context, sessionID string, newSession *guardian.Session) error {
+	s.updates++
+	return s.InMemoryStore.Update(ctx, sessionID, newSession)
+}
+
+// TestSession_TypedAccessors exercises Set/Get and the typed Get helpers.
+func TestSession_TypedAccessors(t *testing.T) {
+	var session guardian.Session
+
+	session.Set("name", "gopher")
+	session.Set("age", 7)
+	session.Set("admin", true)
+
+	if got := session.GetString("name"); got != "gopher" {
+		t.Fatalf("GetString(name) = %q, want %q", got, "gopher")
+	}
+	if got := session.GetInt("age"); got != 7 {
+		t.Fatalf("GetInt(age) = %d, want %d", got, 7)
+	}
+	if got := session.GetBool("admin"); !got {
+		t.Fatal("GetBool(admin) = false, want true")
+	}
+	if got := session.GetString("missing"); got != "" {
+		t.Fatalf("GetString(missing) = %q, want empty", got)
+	}
+
+	session.Delete("age")
+	if got := session.Get("age"); got != nil {
+		t.Fatalf("Get(age) after Delete = %v, want nil", got)
+	}
+
+	session.Flush()
+	if got := session.Get("name"); got != nil {
+		t.Fatalf("Get(name) after Flush = %v, want nil", got)
+	}
+}
+
+// TestManager_Release verifies that Release only writes back to the store
+// when the session was mutated.
+func TestManager_Release(t *testing.T) {
+	store := guardian.NewInMemoryStore()
+	manager, err := guardian.NewManager("release_test_manager", store)
+	if err != nil {
+		t.Fatalf("unable to create session manager: %v", err)
+	}
+
+	ctx := context.Background()
+
+	session, err := manager.CreateSession(ctx)
+	if err != nil {
+		t.Fatalf("unable to create session: %v", err)
+	}
+	if !session.Fresh() {
+		t.Fatal("newly created session should be Fresh")
+	}
+
+	session.Set("visits", 1)
+	if err := manager.Release(ctx, session.ID, session); err != nil {
+		t.Fatalf("Release: %v", err)
+	}
+
+	stored, err := manager.GetSession(ctx, session.ID)
+	if err != nil {
+		t.Fatalf("GetSession: %v", err)
+	}
+	if got := stored.GetInt("visits"); got != 1 {
+		t.Fatalf("stored visits = %d, want 1", got)
+	}
+	if stored.Fresh() {
+		t.Fatal("session loaded from the store should not be Fresh")
+	}
+}
+
+// TestManager_Release_SkipsWriteWhenUnmutated verifies that Release does
+// not write back to the store for a still-valid session that was loaded
+// but never mutated through Set/Delete/Flush - only the idle-time bump
+// happens, in memory, for that case.
+func TestManager_Release_SkipsWriteWhenUnmutated(t *testing.T) {
+	store := &releaseTrackingStore{InMemoryStore: guardian.NewInMemoryStore()}
+	manager, err := guardian.NewManager("release_skip_test_manager", store)
+	if err != nil {
+		t.Fatalf("unable to create session manager: %v", err)
+	}
+
+	ctx := context.Background()
+
+	session, err := manager.CreateSession(ctx)
+	if err != nil {
+		t.Fatalf("unable to create session: %v", err)
+	}
+
+	store.updates = 0
+	if err := manager.Release(ctx, session.ID, session); err != nil {
+		t.Fatalf("Release: %v", err)
+	}
+	if store.updates != 0 {
+		t.Fatalf("Release wrote to the store for an unmutated session (Update called %d times, want 0)", store.updates)
+	}
+
+	session.Set("touched", true)
+	if err := manager.Release(ctx, session.ID, session); err != nil {
+		t.Fatalf("Release: %v", err)
+	}
+	if store.updates != 1 {
+		t.Fatalf("Release did not write to the store after a real mutation (Update called %d times, want 1)", store.updates)
+	}
+}
@@ -0,0 +1,58 @@
+package guardian
+
+import (
+	"bytes"
+	"encoding/gob"
+	"time"
+)
+
+// sessionWireFormat mirrors Session's fields that are safe to persist.
+// The mutex and the fresh/dirty bookkeeping are process-local and must
+// not round-trip through a store.
+type sessionWireFormat struct {
+	ID         string
+	Status     int
+	IdleTime   time.Time
+	ExpiryTime time.Time
+	Data       map[string]interface{}
+}
+
+// GobEncode lets gob-based stores (file, redis, sql, cookie) encode a
+// Session despite its data map being unexported.
+func (s *Session) GobEncode() ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var buf bytes.Buffer
+	wire := sessionWireFormat{
+		ID:         s.ID,
+		Status:     s.Status,
+		IdleTime:   s.IdleTime,
+		ExpiryTime: s.ExpiryTime,
+		Data:       s.data,
+	}
+	if err := gob.NewEncoder(&buf).Encode(wire); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode is the counterpart to GobEncode. The decoded Session is
+// never Fresh and starts out clean (not dirty).
+func (s *Session) GobDecode(b []byte) error {
+	var wire sessionWireFormat
+	if err := gob.NewDecoder(bytes.NewReader(b)).Decode(&wire); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ID = wire.ID
+	s.Status = wire.Status
+	s.IdleTime = wire.IdleTime
+	s.ExpiryTime = wire.ExpiryTime
+	s.data = wire.Data
+	s.fresh = false
+	s.dirty = false
+	return nil
+}
@@ -6,37 +6,41 @@ import (
 	"net/http"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/1jack80/guardian"
 )
 
-// MockStorage is a mock implementation of the Storer interface for testing.
+// MockStorage is a mock implementation of the pre-context ContextlessStorer
+// shape, wired up through guardian.WrapContextless so these tests also
+// exercise the compatibility adapter rather than only the native,
+// context-aware InMemoryStore.
 type MockStorage struct {
-	data map[string]guardian.Session
+	data map[string]*guardian.Session
 	mu   sync.RWMutex
 }
 
 // NewMockStorage creates a new instance of MockStorage.
 func NewMockStorage() *MockStorage {
 	return &MockStorage{
-		data: make(map[string]guardian.Session),
+		data: make(map[string]*guardian.Session),
 	}
 }
 
 // get retrieves session data from the mock storage.
-func (s *MockStorage) Get(sessionID string) (guardian.Session, error) {
+func (s *MockStorage) Get(sessionID string) (*guardian.Session, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
 	session, ok := s.data[sessionID]
 	if !ok {
-		return guardian.Session{}, errors.New("Session not found")
+		return nil, errors.New("Session not found")
 	}
 	return session, nil
 }
 
 // save saves a session into the mock storage.
-func (s *MockStorage) Save(session guardian.Session) error {
+func (s *MockStorage) Save(session *guardian.Session) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -54,7 +58,7 @@ func (s *MockStorage) Delete(sessionID string) error {
 }
 
 // Update updates session data in the mock storage.
-func (s *MockStorage) Update(sessionID string, newSession guardian.Session) error {
+func (s *MockStorage) Update(sessionID string, newSession *guardian.Session) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -68,6 +72,24 @@ func (s *MockStorage) Update(sessionID string, newSession guardian.Session) erro
 	}
 }
 
+// GC removes expired or invalidated-and-idle sessions from the mock storage.
+func (s *MockStorage) GC() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for id, session := range s.data {
+		if now.After(session.ExpiryTime) {
+			delete(s.data, id)
+			continue
+		}
+		if session.Status == guardian.INVALID && now.After(session.IdleTime) {
+			delete(s.data, id)
+		}
+	}
+	return nil
+}
+
 func TestValidateNamespace(t *testing.T) {
 	err := guardian.ValidateNamespace("one")
 	if err != nil {
@@ -79,7 +101,7 @@ func TestValidateNamespace(t *testing.T) {
 	}
 }
 
-var store = guardian.NewInMemoryStore()
+var store = guardian.WrapContextless(NewMockStorage())
 var manager, manager_err = guardian.NewManager("test_manager", store)
 
 // TestSessionManager_CreateSession tests the creation of a new session and validates its attributes.
@@ -91,7 +113,7 @@ func TestSessionManager_CreateSession(t *testing.T) {
 	if manager.ContextKey() == "" {
 		t.Fatal("session manager context key is empty")
 	}
-	if manager.SaveSession(guardian.Session{ID: "one"}) != nil {
+	if manager.SaveSession(context.Background(), &guardian.Session{ID: "one"}) != nil {
 		t.Fatalf("manager cannot save session")
 	}
 }
@@ -112,7 +134,10 @@ func TestSessionManager_PopulateRequestContext(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unable to create request: %v", err)
 	}
-	session := manager.CreateSession()
+	session, err := manager.CreateSession(ctx)
+	if err != nil {
+		t.Fatalf("unable to create session: %v", err)
+	}
 
 	req = manager.PopulateRequestContext(req, session)
 
@@ -131,13 +156,18 @@ func TestSessionManager_InvalidateSession(t *testing.T) {
 		t.Fatalf("unable to create session manager: err -- %s", manager_err.Error())
 	}
 
-	session := manager.CreateSession()
+	ctx := context.Background()
+
+	session, err := manager.CreateSession(ctx)
+	if err != nil {
+		t.Fatalf("unable to create session: %v", err)
+	}
 	sessionID := session.ID
 	session.Status = guardian.VALID
 
-	manager.InvalidateSession(sessionID)
+	manager.InvalidateSession(ctx, sessionID)
 
-	session, err := manager.GetSession(sessionID)
+	session, err = manager.GetSession(ctx, sessionID)
 	if err != nil {
 		t.Fatalf("unable to get session from manager")
 	}
@@ -155,10 +185,15 @@ func TestSessionManager_RenewSession(t *testing.T) {
 		t.Fatalf("unable to create session manager: err -- %s", manager_err.Error())
 	}
 
-	session := manager.CreateSession()
+	ctx := context.Background()
+
+	session, err := manager.CreateSession(ctx)
+	if err != nil {
+		t.Fatalf("unable to create session: %v", err)
+	}
 	sessionID := session.ID
 
-	session, err := manager.RenewSession(sessionID)
+	session, err = manager.RenewSession(ctx, sessionID)
 	if err != nil {
 		t.Error(err)
 	}
@@ -166,10 +201,10 @@ func TestSessionManager_RenewSession(t *testing.T) {
 	if sessionID == session.ID {
 		t.Fatal("session id was not renewed")
 	}
-	if _, err := manager.GetSession(sessionID); err == nil {
+	if _, err := manager.GetSession(ctx, sessionID); err == nil {
 		t.Fatal("old session id not updated in the store")
 	}
-	if _, err := manager.GetSession(session.ID); err != nil {
+	if _, err := manager.GetSession(ctx, session.ID); err != nil {
 		t.Fatal("new sesson id was not added to the store")
 	}
 }
@@ -1,25 +1,107 @@
 package guardian
 
+import (
+	"context"
+	"errors"
+)
+
 /*
 	ERROR TYPES:
 		- NOT FOUND
 */
 
+var errUnsupportedByContextlessStorer = errors.New("guardian: Reset is not supported by a wrapped ContextlessStorer")
+
 type Storer interface {
 	// retrieve the sesison data from the underlying container
 	// and decode it before returning it to the calling function
-	Get(sessionID string) (Session, error)
+	Get(ctx context.Context, sessionID string) (*Session, error)
 
 	// save an encoded form of the given session data into
 	// the underlying container
-	Save(session Session) error
+	Save(ctx context.Context, session *Session) error
 
 	// delete the session identified by the given sessionID
-	Delete(sessionID string) error
+	Delete(ctx context.Context, sessionID string) error
 
 	// update parts of the session that identifes with the given sessionID:
 	// the new session is used to replace the old session hance,
 	// using this function requires that a pointer to the updated
 	// copy of the old session is created an passed to this function.
-	Update(sessionID string, newSession Session) error
+	Update(ctx context.Context, sessionID string, newSession *Session) error
+
+	// GC sweeps expired and invalidated sessions from the underlying
+	// container. Stores backed by something that already expires keys on
+	// its own (e.g. Redis TTLs) may implement this as a no-op.
+	GC(ctx context.Context) error
+
+	// Reset clears every session the store holds. Stores shared across
+	// namespaces should document what, exactly, gets cleared.
+	Reset(ctx context.Context) error
+
+	// Close releases any resources the store holds (connections, file
+	// handles, ...). Callers that share a store across multiple Managers
+	// are responsible for only closing it once, after every Manager using
+	// it is done.
+	Close(ctx context.Context) error
+}
+
+// ContextlessStorer is the pre-context Storer shape. It exists so stores
+// written before Storer took a context (or third-party stores that have
+// no need for one) can still be used via WrapContextless instead of being
+// rewritten.
+type ContextlessStorer interface {
+	Get(sessionID string) (*Session, error)
+	Save(session *Session) error
+	Delete(sessionID string) error
+	Update(sessionID string, newSession *Session) error
+	GC() error
+}
+
+// contextlessStorer adapts a ContextlessStorer to Storer by ignoring
+// whatever context it's given; none of its calls can actually be
+// cancelled or time out.
+type contextlessStorer struct {
+	ContextlessStorer
+}
+
+// WrapContextless upgrades a ContextlessStorer into a Storer so it can be
+// passed to NewManager/NewManagerWithOptions. This is a compatibility
+// shim, not a recommendation: a wrapped store still can't be cancelled or
+// have a deadline enforced on it.
+func WrapContextless(store ContextlessStorer) Storer {
+	return contextlessStorer{ContextlessStorer: store}
+}
+
+func (c contextlessStorer) Get(ctx context.Context, sessionID string) (*Session, error) {
+	return c.ContextlessStorer.Get(sessionID)
+}
+
+func (c contextlessStorer) Save(ctx context.Context, session *Session) error {
+	return c.ContextlessStorer.Save(session)
+}
+
+func (c contextlessStorer) Delete(ctx context.Context, sessionID string) error {
+	return c.ContextlessStorer.Delete(sessionID)
+}
+
+func (c contextlessStorer) Update(ctx context.Context, sessionID string, newSession *Session) error {
+	return c.ContextlessStorer.Update(sessionID, newSession)
+}
+
+func (c contextlessStorer) GC(ctx context.Context) error {
+	return c.ContextlessStorer.GC()
+}
+
+// Reset is unsupported for a wrapped ContextlessStorer since its original
+// interface never defined one.
+func (c contextlessStorer) Reset(ctx context.Context) error {
+	return errUnsupportedByContextlessStorer
+}
+
+// Close is a no-op for a wrapped ContextlessStorer since its original
+// interface never defined one; the caller remains responsible for closing
+// whatever resources the wrapped store holds.
+func (c contextlessStorer) Close(ctx context.Context) error {
+	return nil
 }
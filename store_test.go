@@ -0,0 +1,66 @@
+package guardian_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/1jack80/guardian"
+)
+
+// TestInMemoryStore_ContextCancelled verifies that InMemoryStore's methods
+// refuse to run once the passed context is already done.
+func TestInMemoryStore_ContextCancelled(t *testing.T) {
+	store := guardian.NewInMemoryStore()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := store.Save(ctx, &guardian.Session{ID: "one"}); err == nil {
+		t.Fatal("Save should fail with a cancelled context")
+	}
+	if _, err := store.Get(ctx, "one"); err == nil {
+		t.Fatal("Get should fail with a cancelled context")
+	}
+	if err := store.Reset(ctx); err == nil {
+		t.Fatal("Reset should fail with a cancelled context")
+	}
+}
+
+// TestInMemoryStore_Reset verifies Reset discards every session the store
+// holds.
+func TestInMemoryStore_Reset(t *testing.T) {
+	store := guardian.NewInMemoryStore()
+	ctx := context.Background()
+
+	if err := store.Save(ctx, &guardian.Session{ID: "one"}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if err := store.Reset(ctx); err != nil {
+		t.Fatalf("Reset: %v", err)
+	}
+	if _, err := store.Get(ctx, "one"); err == nil {
+		t.Fatal("expected session to be gone after Reset")
+	}
+}
+
+// TestWrapContextless verifies that a ContextlessStorer adapted via
+// WrapContextless still behaves like a Storer, ignoring whatever context
+// it's given, and that Reset correctly reports itself unsupported.
+func TestWrapContextless(t *testing.T) {
+	mock := NewMockStorage()
+	wrapped := guardian.WrapContextless(mock)
+	ctx := context.Background()
+
+	if err := wrapped.Save(ctx, &guardian.Session{ID: "one"}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if _, err := wrapped.Get(ctx, "one"); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if err := wrapped.Reset(ctx); err == nil {
+		t.Fatal("Reset should be unsupported on a wrapped ContextlessStorer")
+	}
+	if err := wrapped.Close(ctx); err != nil {
+		t.Fatalf("Close should be a no-op, got: %v", err)
+	}
+}